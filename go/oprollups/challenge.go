@@ -0,0 +1,255 @@
+package oprollups
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	store "github.com/rogercoll/oprollups/contracts"
+)
+
+// StateTransition lets a caller plug in its own execution engine so
+// SubmitBatch and the Challenger can locally replay the transactions of a
+// batch and compare the resulting root against what was claimed on-chain.
+type StateTransition interface {
+	Apply(prevRoot common.Hash, tx []byte) (newRoot common.Hash, err error)
+}
+
+// stepLeaf is the Merkle leaf committing to a single transition: its
+// pre-state root, the transaction bytes, and its claimed post-state root.
+// Committing the roots alongside the transaction, rather than the
+// transaction alone, is what lets a dispute identify exactly which
+// transition is wrong instead of only knowing that some transition in the
+// batch is.
+func stepLeaf(prevRoot common.Hash, tx []byte, postRoot common.Hash) []byte {
+	return crypto.Keccak256(prevRoot.Bytes(), tx, postRoot.Bytes())
+}
+
+// SubmitBatch re-executes txs locally through st to make sure prevRoot
+// really transitions to newRoot, builds the Merkle root committing to the
+// individual transitions (and their claimed intermediate roots), and
+// submits the batch on-chain.
+func (ori *Oprollups) SubmitBatch(ctx context.Context, prevRoot, newRoot common.Hash, txs [][]byte, st StateTransition, signer Signer) error {
+	batchRoot, stepRoots, err := replayBatch(st, prevRoot, newRoot, txs)
+	if err != nil {
+		return fmt.Errorf("refusing to submit a batch that fails local replay: %w", err)
+	}
+
+	opts, err := signer.TransactOpts(ctx, ori.ethClient)
+	if err != nil {
+		return err
+	}
+	_, err = ori.ori_contract.SubmitBatch(opts, prevRoot, newRoot, batchRoot, stepRoots)
+	return wrapRevertError(err)
+}
+
+// replayBatch re-executes every transition in txs through st starting at
+// prevRoot, and returns the Merkle root committing to every (prevRoot, tx,
+// postRoot) step alongside the per-step roots themselves, or an error if
+// the replayed root diverges from newRoot.
+func replayBatch(st StateTransition, prevRoot, newRoot common.Hash, txs [][]byte) (batchRoot common.Hash, stepRoots []common.Hash, err error) {
+	leaves := make([][]byte, len(txs))
+	stepRoots = make([]common.Hash, len(txs))
+	root := prevRoot
+	for i, tx := range txs {
+		next, err := st.Apply(root, tx)
+		if err != nil {
+			return common.Hash{}, nil, fmt.Errorf("applying tx %d: %w", i, err)
+		}
+		leaves[i] = stepLeaf(root, tx, next)
+		stepRoots[i] = next
+		root = next
+	}
+	if root != newRoot {
+		return common.Hash{}, nil, fmt.Errorf("replayed root %s does not match claimed root %s", root, newRoot)
+	}
+	tree, err := NewMerkleTree(leaves)
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+	return tree.Root(), stepRoots, nil
+}
+
+// Challenge disputes transition txIndex of batchID, submitting the exact
+// (prevRoot, tx, postRoot) triple committed by the batch along with a
+// Merkle inclusion proof of that triple. The contract re-executes tx from
+// prevRoot itself and only needs to compare the result against postRoot;
+// it never trusts prevRoot/tx/postRoot as given, only that they're part of
+// the batch the submitter committed to.
+func (ori *Oprollups) Challenge(ctx context.Context, batchID *big.Int, txIndex uint64, prevRoot common.Hash, tx []byte, postRoot common.Hash, proof [][]byte, signer Signer) error {
+	opts, err := signer.TransactOpts(ctx, ori.ethClient)
+	if err != nil {
+		return err
+	}
+	_, err = ori.ori_contract.Challenge(opts, batchID, new(big.Int).SetUint64(txIndex), prevRoot, tx, postRoot, proof)
+	return wrapRevertError(err)
+}
+
+// Challenger watches submitted batches and automatically challenges any
+// whose locally replayed root disagrees with what was claimed on-chain.
+type Challenger struct {
+	ori    *Oprollups
+	st     StateTransition
+	signer Signer
+	errCh  chan error
+}
+
+// NewChallenger returns a Challenger that replays every batch observed via
+// StateRootSubmitted events through st, and auto-challenges using signer on
+// any mismatch.
+func (ori *Oprollups) NewChallenger(st StateTransition, signer Signer) *Challenger {
+	return &Challenger{ori: ori, st: st, signer: signer, errCh: make(chan error, 1)}
+}
+
+// Err returns a channel on which errors encountered while verifying or
+// auto-challenging batches are reported. Errors of the underlying
+// StateRootSubmitted subscription are reported on the Oprollups instance's
+// own Err channel instead.
+func (c *Challenger) Err() <-chan error {
+	return c.errCh
+}
+
+// Watch subscribes to StateRootSubmitted events and blocks until ctx is
+// cancelled, challenging every batch it cannot locally reproduce.
+func (c *Challenger) Watch(ctx context.Context) error {
+	submissions := make(chan *StateRootSubmittedEvent)
+	go func() {
+		if err := c.ori.WatchStateRootSubmitted(ctx, submissions); err != nil && ctx.Err() == nil {
+			select {
+			case c.errCh <- err:
+			default:
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case evt := <-submissions:
+			if err := c.verifyAndChallenge(ctx, evt); err != nil {
+				select {
+				case c.errCh <- err:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// firstDivergence replays txs through st starting at prevRoot and returns
+// the index of the first transition where the replay disagrees with the
+// batch's own claims: either st can't apply the transition at all, or its
+// result doesn't match the step root the batch committed to for that
+// index. ok is false if the whole batch replays cleanly, including its
+// final root matching newRoot.
+//
+// The search always stops at the first disagreement rather than only
+// checking the last transition: once one step's claimed root diverges from
+// a correct replay, every step built on top of it is replaying from an
+// already-wrong prevRoot and can't be trusted, honest-looking or not.
+func firstDivergence(st StateTransition, prevRoot common.Hash, txs [][]byte, stepRoots []common.Hash, newRoot common.Hash) (index int, ok bool) {
+	root := prevRoot
+	for i, tx := range txs {
+		next, err := st.Apply(root, tx)
+		if err != nil || next != stepRoots[i] {
+			return i, true
+		}
+		if i == len(txs)-1 && next != newRoot {
+			return i, true
+		}
+		root = stepRoots[i]
+	}
+	return 0, false
+}
+
+// verifyAndChallenge fetches the batch's committed transactions and
+// per-step roots published alongside evt, replays them through c.st, and
+// challenges the first transition it can't reproduce, if any.
+func (c *Challenger) verifyAndChallenge(ctx context.Context, evt *StateRootSubmittedEvent) error {
+	txs, stepRoots, err := c.fetchBatchData(ctx, evt.Raw.TxHash)
+	if err != nil {
+		return fmt.Errorf("fetching batch %s data: %w", evt.BatchId, err)
+	}
+	if len(txs) != len(stepRoots) {
+		return fmt.Errorf("batch %s: got %d txs but %d step roots", evt.BatchId, len(txs), len(stepRoots))
+	}
+
+	index, diverges := firstDivergence(c.st, evt.PrevRoot, txs, stepRoots, evt.NewRoot)
+	if !diverges {
+		return nil
+	}
+
+	leaves := make([][]byte, len(txs))
+	prevRoot := evt.PrevRoot
+	for i, tx := range txs {
+		leaves[i] = stepLeaf(prevRoot, tx, stepRoots[i])
+		prevRoot = stepRoots[i]
+	}
+	tree, err := NewMerkleTree(leaves)
+	if err != nil {
+		return err
+	}
+	proof, err := tree.Proof(index)
+	if err != nil {
+		return err
+	}
+
+	stepPrevRoot := evt.PrevRoot
+	if index > 0 {
+		stepPrevRoot = stepRoots[index-1]
+	}
+	return c.ori.Challenge(ctx, evt.BatchId, uint64(index), stepPrevRoot, txs[index], stepRoots[index], proof, c.signer)
+}
+
+// submitBatchABI is used to recover the raw transaction list and claimed
+// step roots a batch submission published as calldata, since the contract
+// itself only stores the resulting batch root.
+var submitBatchABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(store.ContractsMetaData.ABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// fetchBatchData recovers the transaction list and per-step claimed roots
+// passed to SubmitBatch by decoding the calldata of the transaction that
+// emitted the event.
+func (c *Challenger) fetchBatchData(ctx context.Context, txHash common.Hash) (txs [][]byte, stepRoots []common.Hash, err error) {
+	tx, _, err := c.ori.ethClient.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	data := tx.Data()
+	if len(data) < 4 {
+		return nil, nil, fmt.Errorf("calldata too short to contain a method selector")
+	}
+	method, err := submitBatchABI.MethodById(data[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case [][]byte:
+			txs = v
+		case []common.Hash:
+			stepRoots = v
+		}
+	}
+	if txs == nil {
+		return nil, nil, fmt.Errorf("submitBatch calldata did not contain a tx list argument")
+	}
+	if stepRoots == nil {
+		return nil, nil, fmt.Errorf("submitBatch calldata did not contain a step root list argument")
+	}
+	return txs, stepRoots, nil
+}
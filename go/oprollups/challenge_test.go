@@ -0,0 +1,158 @@
+package oprollups
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// hashChainST is a deterministic StateTransition for tests: applying tx to
+// prevRoot always yields keccak256(prevRoot, tx), unless overridden for a
+// specific prevRoot/tx pair to simulate a submitter lying about a step.
+type hashChainST struct {
+	overrides map[string]common.Hash
+	errAt     map[string]error
+}
+
+func (st *hashChainST) key(prevRoot common.Hash, tx []byte) string {
+	return string(append(append([]byte{}, prevRoot.Bytes()...), tx...))
+}
+
+func (st *hashChainST) Apply(prevRoot common.Hash, tx []byte) (common.Hash, error) {
+	k := st.key(prevRoot, tx)
+	if err, ok := st.errAt[k]; ok {
+		return common.Hash{}, err
+	}
+	if root, ok := st.overrides[k]; ok {
+		return root, nil
+	}
+	return crypto.Keccak256Hash(prevRoot.Bytes(), tx), nil
+}
+
+func honestSteps(st *hashChainST, prevRoot common.Hash, txs [][]byte) []common.Hash {
+	roots := make([]common.Hash, len(txs))
+	root := prevRoot
+	for i, tx := range txs {
+		next, _ := st.Apply(root, tx)
+		roots[i] = next
+		root = next
+	}
+	return roots
+}
+
+func TestFirstDivergenceCleanBatch(t *testing.T) {
+	st := &hashChainST{}
+	prevRoot := crypto.Keccak256Hash([]byte("genesis"))
+	txs := [][]byte{[]byte("tx-0"), []byte("tx-1"), []byte("tx-2")}
+	stepRoots := honestSteps(st, prevRoot, txs)
+
+	if _, diverges := firstDivergence(st, prevRoot, txs, stepRoots, stepRoots[len(stepRoots)-1]); diverges {
+		t.Fatal("expected a clean batch to report no divergence")
+	}
+}
+
+func TestFirstDivergenceApplyError(t *testing.T) {
+	st := &hashChainST{errAt: map[string]error{}}
+	prevRoot := crypto.Keccak256Hash([]byte("genesis"))
+	txs := [][]byte{[]byte("tx-0"), []byte("tx-1"), []byte("tx-2")}
+	stepRoots := honestSteps(st, prevRoot, txs)
+
+	root1, _ := st.Apply(prevRoot, txs[0])
+	st.errAt[st.key(root1, txs[1])] = errors.New("boom")
+
+	index, diverges := firstDivergence(st, prevRoot, txs, stepRoots, stepRoots[len(stepRoots)-1])
+	if !diverges {
+		t.Fatal("expected a divergence to be reported")
+	}
+	if index != 1 {
+		t.Fatalf("index = %d, want 1", index)
+	}
+}
+
+// TestFirstDivergenceMidBatchWrongStepRoot is the regression test for the
+// review bug: a mid-batch transition that diverges from an honest replay
+// but doesn't make Apply return an error must still be caught at its own
+// index, not carried forward and blamed on the last transaction.
+func TestFirstDivergenceMidBatchWrongStepRoot(t *testing.T) {
+	st := &hashChainST{}
+	prevRoot := crypto.Keccak256Hash([]byte("genesis"))
+	txs := [][]byte{[]byte("tx-0"), []byte("tx-1"), []byte("tx-2"), []byte("tx-3")}
+	stepRoots := honestSteps(st, prevRoot, txs)
+
+	// The submitter claims a bogus root for step 1 (no error, just wrong),
+	// while the claimed roots for the later steps stay the honest ones.
+	claimedStepRoots := append([]common.Hash{}, stepRoots...)
+	claimedStepRoots[1] = crypto.Keccak256Hash([]byte("forged-root"))
+
+	index, diverges := firstDivergence(st, prevRoot, txs, claimedStepRoots, claimedStepRoots[len(claimedStepRoots)-1])
+	if !diverges {
+		t.Fatal("expected a divergence to be reported")
+	}
+	if index != 1 {
+		t.Fatalf("index = %d, want 1 (the actual forged step, not len(txs)-1)", index)
+	}
+}
+
+func TestFirstDivergenceFinalRootMismatch(t *testing.T) {
+	st := &hashChainST{}
+	prevRoot := crypto.Keccak256Hash([]byte("genesis"))
+	txs := [][]byte{[]byte("tx-0"), []byte("tx-1")}
+	stepRoots := honestSteps(st, prevRoot, txs)
+
+	bogusNewRoot := crypto.Keccak256Hash([]byte("not-what-was-replayed"))
+	index, diverges := firstDivergence(st, prevRoot, txs, stepRoots, bogusNewRoot)
+	if !diverges {
+		t.Fatal("expected a divergence to be reported")
+	}
+	if index != len(txs)-1 {
+		t.Fatalf("index = %d, want %d", index, len(txs)-1)
+	}
+}
+
+// TestChallengeProofMatchesDivergingIndex exercises the same leaf-building
+// and proof lookup verifyAndChallenge performs, without requiring a live
+// chain: it builds the per-step Merkle tree for a batch with a forged
+// mid-batch step, locates the divergence with firstDivergence, and checks
+// that tree.Proof(index) verifies against exactly that step's leaf and no
+// other -- the scenario that used to break when Proof looked leaves up by
+// content instead of by index.
+func TestChallengeProofMatchesDivergingIndex(t *testing.T) {
+	st := &hashChainST{}
+	prevRoot := crypto.Keccak256Hash([]byte("genesis"))
+	// tx-1 and tx-2 are identical bytes, so a content-based index lookup
+	// would be ambiguous between them.
+	txs := [][]byte{[]byte("tx-0"), []byte("dup"), []byte("dup")}
+	stepRoots := honestSteps(st, prevRoot, txs)
+
+	claimedStepRoots := append([]common.Hash{}, stepRoots...)
+	claimedStepRoots[2] = crypto.Keccak256Hash([]byte("forged-root"))
+
+	index, diverges := firstDivergence(st, prevRoot, txs, claimedStepRoots, claimedStepRoots[len(claimedStepRoots)-1])
+	if !diverges || index != 2 {
+		t.Fatalf("firstDivergence = (%d, %v), want (2, true)", index, diverges)
+	}
+
+	leaves := make([][]byte, len(txs))
+	root := prevRoot
+	for i, tx := range txs {
+		leaves[i] = stepLeaf(root, tx, claimedStepRoots[i])
+		root = claimedStepRoots[i]
+	}
+	tree, err := NewMerkleTree(leaves)
+	if err != nil {
+		t.Fatalf("new merkle tree: %v", err)
+	}
+
+	proof, err := tree.Proof(index)
+	if err != nil {
+		t.Fatalf("proof(%d): %v", index, err)
+	}
+	if !VerifyMerkleProof(tree.Root(), leaves[index], proof) {
+		t.Fatal("proof did not verify against the diverging step's own leaf")
+	}
+	if VerifyMerkleProof(tree.Root(), leaves[1], proof) {
+		t.Fatal("proof for index 2 must not also verify against the duplicate leaf at index 1")
+	}
+}
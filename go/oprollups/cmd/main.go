@@ -2,13 +2,9 @@ package main
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"log"
-	"math/big"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rogercoll/oprollups"
 )
@@ -24,41 +20,18 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	err = opr.LockTime()
+	lockTime, err := opr.LockTime()
 	if err != nil {
 		log.Fatal(err)
 	}
+	log.Printf("Total lock time: %s", lockTime)
 
-	privateKey, err := crypto.HexToECDSA("cd40c0e859b7f6ebf942ee4b2f923acbe54546e9339a025de4b173f442187828")
+	signer, err := oprollups.NewRawKeySigner("cd40c0e859b7f6ebf942ee4b2f923acbe54546e9339a025de4b173f442187828")
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		log.Fatal("error casting public key to ECDSA")
-	}
-
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	gasPrice, err := client.SuggestGasPrice(context.Background())
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	auth := bind.NewKeyedTransactor(privateKey)
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = big.NewInt(100)   // in wei
-	auth.GasLimit = uint64(300000) // in units
-	auth.GasPrice = gasPrice
-
-	err = opr.Bond(auth, fromAddress)
+	err = opr.Bond(context.Background(), signer)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,45 @@
+package oprollups
+
+import (
+	"errors"
+	"strings"
+)
+
+// Sentinel errors returned by Oprollups methods so callers can branch with
+// errors.Is/errors.As instead of matching against error strings.
+var (
+	// ErrAlreadyBonded is returned by Bond when the caller's bonded
+	// balance already meets the contract's required bond.
+	ErrAlreadyBonded = errors.New("already bonded")
+
+	// ErrInsufficientValue is returned when a write call reverts because
+	// the transaction did not carry enough value to satisfy the
+	// contract's requirement.
+	ErrInsufficientValue = errors.New("insufficient value sent")
+)
+
+// knownRevertReasons maps substrings of the contract's own revert reasons
+// onto the package's sentinel errors. It intentionally does not match
+// broader substrings like "insufficient" alone, since go-ethereum's own
+// pre-send balance check ("insufficient funds for gas * price + value")
+// would otherwise be misreported as a contract-side value error.
+var knownRevertReasons = map[string]error{
+	"insufficient value sent": ErrInsufficientValue,
+	"already bonded":          ErrAlreadyBonded,
+}
+
+// wrapRevertError maps known on-chain revert reasons onto the package's
+// sentinel errors, so a caller checking errors.Is doesn't need to know the
+// exact revert string the contract uses.
+func wrapRevertError(err error) error {
+	if err == nil {
+		return nil
+	}
+	reason := strings.ToLower(err.Error())
+	for substr, sentinel := range knownRevertReasons {
+		if strings.Contains(reason, substr) {
+			return sentinel
+		}
+	}
+	return err
+}
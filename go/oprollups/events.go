@@ -0,0 +1,137 @@
+package oprollups
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/event"
+	store "github.com/rogercoll/oprollups/contracts"
+)
+
+// Typed aliases over the generated contract event structs so callers don't
+// need to import the contracts package directly.
+type (
+	BondedEvent             = store.ContractsBonded
+	StateRootSubmittedEvent = store.ContractsStateRootSubmitted
+	ChallengedEvent         = store.ContractsChallenged
+	WithdrawnEvent          = store.ContractsWithdrawn
+)
+
+// Bounds on the delay between resubscription attempts after a watch's
+// underlying subscription drops.
+const (
+	reconnectBaseDelay = 500 * time.Millisecond
+	reconnectMaxDelay  = 30 * time.Second
+)
+
+// Err returns a channel on which every failed subscribe attempt and every
+// dropped subscription is reported while the Watch* call keeps retrying.
+// It is shared across every Watch* call made on this Oprollups instance.
+func (ori *Oprollups) Err() <-chan error {
+	return ori.errCh
+}
+
+func (ori *Oprollups) emitErr(err error) {
+	select {
+	case ori.errCh <- err:
+	default:
+	}
+}
+
+// WatchBonded streams Bonded events emitted by the contract into ch until
+// ctx is cancelled, resubscribing with an exponential backoff whenever the
+// underlying subscription drops.
+func (ori *Oprollups) WatchBonded(ctx context.Context, ch chan<- *BondedEvent) error {
+	return watchEvents(ori, ctx, "bonded", ch, func(opts *bind.WatchOpts, sink chan *store.ContractsBonded) (event.Subscription, error) {
+		return ori.ori_contract.WatchBonded(opts, sink, nil)
+	})
+}
+
+// WatchStateRootSubmitted streams StateRootSubmitted events into ch until
+// ctx is cancelled, reconnecting on dropped subscriptions.
+func (ori *Oprollups) WatchStateRootSubmitted(ctx context.Context, ch chan<- *StateRootSubmittedEvent) error {
+	return watchEvents(ori, ctx, "state root submitted", ch, func(opts *bind.WatchOpts, sink chan *store.ContractsStateRootSubmitted) (event.Subscription, error) {
+		return ori.ori_contract.WatchStateRootSubmitted(opts, sink)
+	})
+}
+
+// WatchChallenged streams Challenged events into ch until ctx is cancelled,
+// reconnecting on dropped subscriptions.
+func (ori *Oprollups) WatchChallenged(ctx context.Context, ch chan<- *ChallengedEvent) error {
+	return watchEvents(ori, ctx, "challenged", ch, func(opts *bind.WatchOpts, sink chan *store.ContractsChallenged) (event.Subscription, error) {
+		return ori.ori_contract.WatchChallenged(opts, sink)
+	})
+}
+
+// WatchWithdrawn streams Withdrawn events into ch until ctx is cancelled,
+// reconnecting on dropped subscriptions.
+func (ori *Oprollups) WatchWithdrawn(ctx context.Context, ch chan<- *WithdrawnEvent) error {
+	return watchEvents(ori, ctx, "withdrawn", ch, func(opts *bind.WatchOpts, sink chan *store.ContractsWithdrawn) (event.Subscription, error) {
+		return ori.ori_contract.WatchWithdrawn(opts, sink)
+	})
+}
+
+// watchEvents is the reconnect/backoff/demultiplex loop shared by every
+// Watch* method: it opens a filterer subscription via subscribe, forwards
+// everything it receives to ch, and transparently resubscribes with an
+// exponential backoff whenever the underlying subscription drops. label
+// only affects the error text reported on Err(). It returns once ctx is
+// cancelled.
+func watchEvents[T any](ori *Oprollups, ctx context.Context, label string, ch chan<- *T, subscribe func(*bind.WatchOpts, chan *T) (event.Subscription, error)) error {
+	delay := reconnectBaseDelay
+	for {
+		sink := make(chan *T)
+		sub, err := subscribe(&bind.WatchOpts{Context: ctx}, sink)
+		if err != nil {
+			ori.emitErr(fmt.Errorf("subscribe %s: %w", label, err))
+			if waitErr := sleepOrDone(ctx, &delay); waitErr != nil {
+				return waitErr
+			}
+			continue
+		}
+		delay = reconnectBaseDelay
+		if done := demux(ctx, sub, sink, ch); done {
+			return ctx.Err()
+		}
+		ori.emitErr(fmt.Errorf("%s subscription dropped, reconnecting", label))
+	}
+}
+
+// demux forwards events from sink to out until the subscription errors out
+// or ctx is cancelled. It reports true when ctx is cancelled (the caller
+// should stop watching) and false when the subscription merely dropped and
+// should be reopened.
+func demux[T any](ctx context.Context, sub event.Subscription, sink chan *T, out chan<- *T) bool {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case evt := <-sink:
+			select {
+			case out <- evt:
+			case <-ctx.Done():
+				return true
+			}
+		case <-sub.Err():
+			return false
+		}
+	}
+}
+
+// sleepOrDone waits for delay, doubling it (capped at reconnectMaxDelay) for
+// the next call, or returns ctx.Err() if ctx is cancelled first.
+func sleepOrDone(ctx context.Context, delay *time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(*delay):
+		*delay *= 2
+		if *delay > reconnectMaxDelay {
+			*delay = reconnectMaxDelay
+		}
+		return nil
+	}
+}
@@ -0,0 +1,158 @@
+package oprollups
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	store "github.com/rogercoll/oprollups/contracts"
+)
+
+// testChain deploys the Contracts binding on an in-memory simulated.Backend
+// and returns it along with an auth signing for the single funded account
+// and the deployed contract handle.
+func testChain(t *testing.T) (*simulated.Backend, *bind.TransactOpts, *store.Contracts) {
+	t.Helper()
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1337))
+	if err != nil {
+		t.Fatalf("transactor: %v", err)
+	}
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		auth.From: {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+	})
+	t.Cleanup(func() { backend.Close() })
+
+	requiredBond := big.NewInt(1e18)
+	lockTime := big.NewInt(60)
+	_, _, contract, err := store.DeployContracts(auth, backend.Client(), requiredBond, lockTime)
+	if err != nil {
+		t.Fatalf("deploy contracts: %v", err)
+	}
+	backend.Commit()
+
+	return backend, auth, contract
+}
+
+func waitForEvent[T any](t *testing.T, ctx context.Context, ch <-chan *T) *T {
+	t.Helper()
+	select {
+	case evt := <-ch:
+		return evt
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for event")
+		return nil
+	}
+}
+
+func TestWatchBonded(t *testing.T) {
+	backend, auth, contract := testChain(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ori := &Oprollups{ori_contract: contract, ethClient: backend.Client(), required_bond: big.NewInt(1e18), errCh: make(chan error, 1), logger: &stdLogger{}}
+
+	ch := make(chan *BondedEvent)
+	go ori.WatchBonded(ctx, ch)
+
+	bondOpts := *auth
+	bondOpts.Value = big.NewInt(1e18)
+	if _, err := contract.Bond(&bondOpts, auth.From); err != nil {
+		t.Fatalf("bond: %v", err)
+	}
+	backend.Commit()
+
+	evt := waitForEvent(t, ctx, ch)
+	if evt.User != auth.From {
+		t.Fatalf("unexpected bonded user: got %s, want %s", evt.User, auth.From)
+	}
+}
+
+func TestWatchStateRootSubmitted(t *testing.T) {
+	backend, auth, contract := testChain(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ori := &Oprollups{ori_contract: contract, ethClient: backend.Client(), required_bond: big.NewInt(1e18), errCh: make(chan error, 1), logger: &stdLogger{}}
+
+	ch := make(chan *StateRootSubmittedEvent)
+	go ori.WatchStateRootSubmitted(ctx, ch)
+
+	prevRoot := common.Hash{}
+	newRoot := crypto.Keccak256Hash([]byte("new-state"))
+	batchRoot := crypto.Keccak256Hash([]byte("tx-1"))
+	stepRoots := []common.Hash{newRoot}
+	if _, err := contract.SubmitBatch(auth, prevRoot, newRoot, batchRoot, stepRoots); err != nil {
+		t.Fatalf("submit batch: %v", err)
+	}
+	backend.Commit()
+
+	evt := waitForEvent(t, ctx, ch)
+	if evt.NewRoot != newRoot {
+		t.Fatalf("unexpected new root: got %s, want %s", evt.NewRoot, newRoot)
+	}
+}
+
+func TestWatchChallenged(t *testing.T) {
+	backend, auth, contract := testChain(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ori := &Oprollups{ori_contract: contract, ethClient: backend.Client(), required_bond: big.NewInt(1e18), errCh: make(chan error, 1), logger: &stdLogger{}}
+
+	ch := make(chan *ChallengedEvent)
+	go ori.WatchChallenged(ctx, ch)
+
+	tx := []byte("disputed-tx")
+	if _, err := contract.Challenge(auth, big.NewInt(0), big.NewInt(0), common.Hash{}, tx, common.Hash{}, [][]byte{}); err != nil {
+		t.Fatalf("challenge: %v", err)
+	}
+	backend.Commit()
+
+	waitForEvent(t, ctx, ch)
+}
+
+func TestWatchWithdrawn(t *testing.T) {
+	backend, auth, contract := testChain(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ori := &Oprollups{ori_contract: contract, ethClient: backend.Client(), required_bond: big.NewInt(1e18), errCh: make(chan error, 1), logger: &stdLogger{}}
+
+	bondOpts := *auth
+	bondOpts.Value = big.NewInt(1e18)
+	if _, err := contract.Bond(&bondOpts, auth.From); err != nil {
+		t.Fatalf("bond: %v", err)
+	}
+	backend.Commit()
+	backend.AdjustTime(time.Minute)
+	backend.Commit()
+
+	ch := make(chan *WithdrawnEvent)
+	go ori.WatchWithdrawn(ctx, ch)
+
+	if _, err := contract.Withdraw(auth, auth.From); err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+	backend.Commit()
+
+	evt := waitForEvent(t, ctx, ch)
+	if evt.User != auth.From {
+		t.Fatalf("unexpected withdrawn user: got %s, want %s", evt.User, auth.From)
+	}
+}
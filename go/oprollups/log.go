@@ -0,0 +1,48 @@
+package oprollups
+
+import (
+	"fmt"
+	"log"
+)
+
+// LogLevel identifies the severity of a Logger call.
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// Logger is the structured diagnostic sink Oprollups writes to instead of
+// printing directly to stdout. ctx carries alternating key/value pairs, the
+// same convention as go-ethereum's log.Logger.
+type Logger interface {
+	Log(level LogLevel, msg string, ctx ...interface{})
+}
+
+// stdLogger adapts the standard library's log package into a Logger; it is
+// the default used by New when no Option overrides it.
+type stdLogger struct {
+	*log.Logger
+}
+
+func (l *stdLogger) Log(level LogLevel, msg string, ctx ...interface{}) {
+	l.Printf("[%s] %s %v", level, msg, ctx)
+}
+
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LogLevel(%d)", int(l))
+	}
+}
@@ -0,0 +1,98 @@
+package oprollups
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrProofNotFound is returned by MerkleTree.Proof when asked for the
+// inclusion proof of an index outside the tree's leaves.
+var ErrProofNotFound = errors.New("leaf not part of the tree")
+
+// MerkleTree is a binary Merkle tree over sorted keccak256 pair hashing,
+// used to prove that a single transaction is part of a submitted batch
+// without having to reveal the whole batch on-chain.
+type MerkleTree struct {
+	leaves [][]byte
+	layers [][][]byte
+}
+
+// NewMerkleTree builds a MerkleTree over leaves, which callers in this
+// package populate with stepLeaf(prevRoot, tx, postRoot) for every
+// transition in a batch.
+func NewMerkleTree(leaves [][]byte) (*MerkleTree, error) {
+	if len(leaves) == 0 {
+		return nil, errors.New("merkle tree requires at least one leaf")
+	}
+	layer := make([][]byte, len(leaves))
+	copy(layer, leaves)
+	layers := [][][]byte{layer}
+	for len(layer) > 1 {
+		layer = nextLayer(layer)
+		layers = append(layers, layer)
+	}
+	return &MerkleTree{leaves: leaves, layers: layers}, nil
+}
+
+// nextLayer hashes layer's elements pairwise (sorted before hashing, so the
+// tree is insensitive to sibling order) into the layer above. An odd
+// element out is carried up unchanged.
+func nextLayer(layer [][]byte) [][]byte {
+	var next [][]byte
+	for i := 0; i < len(layer); i += 2 {
+		if i+1 == len(layer) {
+			next = append(next, layer[i])
+			continue
+		}
+		next = append(next, hashPair(layer[i], layer[i+1]))
+	}
+	return next
+}
+
+func hashPair(a, b []byte) []byte {
+	if bytes.Compare(a, b) > 0 {
+		a, b = b, a
+	}
+	return crypto.Keccak256(a, b)
+}
+
+// Root returns the tree's root hash.
+func (t *MerkleTree) Root() common.Hash {
+	top := t.layers[len(t.layers)-1]
+	return common.BytesToHash(top[0])
+}
+
+// Proof returns the sibling hashes proving that the leaf at index is
+// included in the tree, ordered from the bottom layer up. Callers must
+// pass the leaf's own position rather than its value: leaves aren't
+// required to be unique, so looking an index up by content would silently
+// return the proof for the wrong occurrence in a batch with duplicate
+// transaction bytes.
+func (t *MerkleTree) Proof(index int) ([][]byte, error) {
+	if index < 0 || index >= len(t.leaves) {
+		return nil, ErrProofNotFound
+	}
+
+	var proof [][]byte
+	for _, layer := range t.layers[:len(t.layers)-1] {
+		siblingIndex := index ^ 1
+		if siblingIndex < len(layer) {
+			proof = append(proof, layer[siblingIndex])
+		}
+		index /= 2
+	}
+	return proof, nil
+}
+
+// VerifyMerkleProof checks that leaf, combined with proof, hashes up to
+// root using the same sorted-pair convention as MerkleTree.
+func VerifyMerkleProof(root common.Hash, leaf []byte, proof [][]byte) bool {
+	computed := leaf
+	for _, sibling := range proof {
+		computed = hashPair(computed, sibling)
+	}
+	return bytes.Equal(computed, root.Bytes())
+}
@@ -0,0 +1,77 @@
+package oprollups
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestMerkleTreeProofRoundTrip(t *testing.T) {
+	leaves := [][]byte{
+		crypto.Keccak256([]byte("a")),
+		crypto.Keccak256([]byte("b")),
+		crypto.Keccak256([]byte("c")),
+		crypto.Keccak256([]byte("d")),
+		crypto.Keccak256([]byte("e")),
+	}
+	tree, err := NewMerkleTree(leaves)
+	if err != nil {
+		t.Fatalf("new merkle tree: %v", err)
+	}
+
+	for i, leaf := range leaves {
+		proof, err := tree.Proof(i)
+		if err != nil {
+			t.Fatalf("proof(%d): %v", i, err)
+		}
+		if !VerifyMerkleProof(tree.Root(), leaf, proof) {
+			t.Fatalf("proof for index %d did not verify", i)
+		}
+	}
+}
+
+func TestMerkleTreeProofDuplicateLeaves(t *testing.T) {
+	dup := crypto.Keccak256([]byte("same-bytes"))
+	leaves := [][]byte{dup, crypto.Keccak256([]byte("other")), dup}
+	tree, err := NewMerkleTree(leaves)
+	if err != nil {
+		t.Fatalf("new merkle tree: %v", err)
+	}
+
+	proof0, err := tree.Proof(0)
+	if err != nil {
+		t.Fatalf("proof(0): %v", err)
+	}
+	proof2, err := tree.Proof(2)
+	if err != nil {
+		t.Fatalf("proof(2): %v", err)
+	}
+
+	if !VerifyMerkleProof(tree.Root(), dup, proof0) {
+		t.Fatal("proof for index 0 did not verify")
+	}
+	if !VerifyMerkleProof(tree.Root(), dup, proof2) {
+		t.Fatal("proof for index 2 did not verify")
+	}
+	if bytes.Equal(joinProof(proof0), joinProof(proof2)) {
+		t.Fatal("expected distinct proofs for duplicate leaves at different indices")
+	}
+}
+
+func TestMerkleTreeProofOutOfRange(t *testing.T) {
+	tree, err := NewMerkleTree([][]byte{crypto.Keccak256([]byte("only"))})
+	if err != nil {
+		t.Fatalf("new merkle tree: %v", err)
+	}
+	if _, err := tree.Proof(-1); err != ErrProofNotFound {
+		t.Fatalf("proof(-1): got %v, want ErrProofNotFound", err)
+	}
+	if _, err := tree.Proof(1); err != ErrProofNotFound {
+		t.Fatalf("proof(1): got %v, want ErrProofNotFound", err)
+	}
+}
+
+func joinProof(proof [][]byte) []byte {
+	return bytes.Join(proof, nil)
+}
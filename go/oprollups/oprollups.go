@@ -1,9 +1,11 @@
 package oprollups
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"log"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -14,10 +16,25 @@ import (
 type Oprollups struct {
 	ori_addr      common.Address
 	ori_contract  *store.Contracts
+	ethClient     *ethclient.Client
 	required_bond *big.Int
+	errCh         chan error
+	logger        Logger
 }
 
-func New(_ori_addr common.Address, ethClient *ethclient.Client) (*Oprollups, error) {
+// Option configures optional behaviour on New/NewWithWs.
+type Option func(*Oprollups)
+
+// WithLogger replaces the default standard-library logger with l. Every
+// diagnostic Oprollups would otherwise write through the default logger is
+// routed through it instead.
+func WithLogger(l Logger) Option {
+	return func(ori *Oprollups) {
+		ori.logger = l
+	}
+}
+
+func New(_ori_addr common.Address, ethClient *ethclient.Client, opts ...Option) (*Oprollups, error) {
 	instance, err := store.NewContracts(_ori_addr, ethClient)
 	if err != nil {
 		return nil, err
@@ -26,42 +43,100 @@ func New(_ori_addr common.Address, ethClient *ethclient.Client) (*Oprollups, err
 	if err != nil {
 		return nil, err
 	}
-	return &Oprollups{ori_addr: _ori_addr, ori_contract: instance, required_bond: requiredBond}, nil
+	ori := &Oprollups{
+		ori_addr:      _ori_addr,
+		ori_contract:  instance,
+		ethClient:     ethClient,
+		required_bond: requiredBond,
+		errCh:         make(chan error, 1),
+		logger:        &stdLogger{log.Default()},
+	}
+	for _, opt := range opts {
+		opt(ori)
+	}
+	return ori, nil
+}
+
+// NewWithWs behaves exactly like New; it takes a client already connected
+// over websocket (it does not dial one or check the transport itself),
+// which is required for the Watch* event subscriptions:
+// the JSON-RPC `eth_subscribe` methods they rely on are only served over
+// persistent connections (ws/ipc), not plain HTTP.
+func NewWithWs(_ori_addr common.Address, wsClient *ethclient.Client, opts ...Option) (*Oprollups, error) {
+	return New(_ori_addr, wsClient, opts...)
 }
 
 func (ori *Oprollups) Version() string {
 	return "hello"
 }
 
-func (ori *Oprollups) Balance(user common.Address) {
-
+func (ori *Oprollups) Balance(user common.Address) (*big.Int, error) {
+	return ori.ori_contract.Balances(nil, user)
 }
 
-func (ori *Oprollups) Bond(opts *bind.TransactOpts, user common.Address) error {
+func (ori *Oprollups) Bond(ctx context.Context, signer Signer) error {
+	user := signer.Address()
 	acutalBalance, err := ori.ori_contract.Balances(nil, user)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Actual account balance: %v\n", acutalBalance)
-	fmt.Printf("Actual required bond: %v\n", ori.required_bond)
+	ori.logger.Log(LevelDebug, "checked bond balance", "user", user, "balance", acutalBalance, "required", ori.required_bond)
 	if acutalBalance.Cmp(ori.required_bond) >= 0 {
-		return errors.New("Bond not required")
+		return ErrAlreadyBonded
 	}
-	reminder, err := ori.ori_contract.Bond(opts, user)
+	opts, err := signer.TransactOpts(ctx, ori.ethClient)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Reminder weis: %v\n", reminder)
+	opts.Value = new(big.Int).Sub(ori.required_bond, acutalBalance)
+	reminder, err := ori.ori_contract.Bond(opts, user)
+	if err != nil {
+		return wrapRevertError(err)
+	}
+	ori.logger.Log(LevelInfo, "bonded", "user", user, "reminder_wei", reminder)
 	return nil
 }
 
-func (ori *Oprollups) LockTime() error {
+// BondState reports a user's full bonding status from a single call, so
+// callers don't need to stitch Balance, LockTime and contract reads
+// together themselves.
+type BondState struct {
+	Balance     *big.Int
+	Required    *big.Int
+	IsBonded    bool
+	LockedUntil time.Time
+}
+
+// BondState returns user's current bond standing against the contract.
+func (ori *Oprollups) BondState(ctx context.Context, user common.Address) (*BondState, error) {
+	callOpts := &bind.CallOpts{Context: ctx}
+	balance, err := ori.ori_contract.Balances(callOpts, user)
+	if err != nil {
+		return nil, err
+	}
+	bondedAt, err := ori.ori_contract.BondedAt(callOpts, user)
+	if err != nil {
+		return nil, err
+	}
+	lockTime, err := ori.LockTime()
+	if err != nil {
+		return nil, err
+	}
+
+	return &BondState{
+		Balance:     balance,
+		Required:    ori.required_bond,
+		IsBonded:    balance.Cmp(ori.required_bond) >= 0,
+		LockedUntil: time.Unix(bondedAt.Int64(), 0).Add(lockTime),
+	}, nil
+}
+
+func (ori *Oprollups) LockTime() (time.Duration, error) {
 	lockTime, err := ori.ori_contract.LockTime(nil)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	fmt.Printf("Total lock time: %v\n", lockTime)
-	return nil
+	return time.Duration(lockTime.Int64()) * time.Second, nil
 }
 
 func Hello() {
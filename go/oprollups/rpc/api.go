@@ -0,0 +1,146 @@
+// Package rpc exposes the oprollups package over JSON-RPC so that external
+// tools (a JS frontend, a CLI, ...) can drive bonding, withdrawals and the
+// challenge flow without linking the Go library or holding any keys
+// themselves -- the daemon signs on their behalf through the configured
+// Signer.
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/rogercoll/oprollups"
+)
+
+// API implements the "oprollups" JSON-RPC namespace registered by Server.
+// Every write method (bond, withdraw, submitBatch, challenge) signs through
+// signer, so callers never need to hold or transmit a private key.
+type API struct {
+	ori    *oprollups.Oprollups
+	signer oprollups.Signer
+	st     oprollups.StateTransition
+}
+
+// NewAPI returns the oprollups namespace backed by ori. st may be nil if
+// the daemon never needs to accept oprollups_submitBatch calls, which
+// fail without it; Challenge always requires the caller to supply its own
+// proof.
+func NewAPI(ori *oprollups.Oprollups, signer oprollups.Signer, st oprollups.StateTransition) *API {
+	return &API{ori: ori, signer: signer, st: st}
+}
+
+// Bond implements oprollups_bond.
+func (a *API) Bond(ctx context.Context) error {
+	return a.ori.Bond(ctx, a.signer)
+}
+
+// Withdraw implements oprollups_withdraw.
+func (a *API) Withdraw(ctx context.Context, user common.Address) error {
+	return a.ori.Withdraw(ctx, a.signer, user)
+}
+
+// LockTime implements oprollups_lockTime, returning the lock period in
+// seconds.
+func (a *API) LockTime() (hexutil.Uint64, error) {
+	lockTime, err := a.ori.LockTime()
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(lockTime.Seconds()), nil
+}
+
+// Balance implements oprollups_balance.
+func (a *API) Balance(user common.Address) (*hexutil.Big, error) {
+	balance, err := a.ori.Balance(user)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(balance), nil
+}
+
+// SubmitBatch implements oprollups_submitBatch. It requires the API to have
+// been constructed with a non-nil StateTransition so the batch can be
+// replayed locally before it is signed and broadcast.
+func (a *API) SubmitBatch(ctx context.Context, prevRoot, newRoot common.Hash, txs []hexutil.Bytes) error {
+	if a.st == nil {
+		return fmt.Errorf("oprollups_submitBatch: no state transition configured on this node")
+	}
+	return a.ori.SubmitBatch(ctx, prevRoot, newRoot, fromHexBytes(txs), a.st, a.signer)
+}
+
+// Challenge implements oprollups_challenge.
+func (a *API) Challenge(ctx context.Context, batchID *big.Int, txIndex hexutil.Uint64, prevRoot common.Hash, tx hexutil.Bytes, postRoot common.Hash, proof []hexutil.Bytes) error {
+	return a.ori.Challenge(ctx, batchID, uint64(txIndex), prevRoot, tx, postRoot, fromHexBytes(proof), a.signer)
+}
+
+func fromHexBytes(in []hexutil.Bytes) [][]byte {
+	out := make([][]byte, len(in))
+	for i, b := range in {
+		out[i] = b
+	}
+	return out
+}
+
+// subscriptionKind enumerates the event streams oprollups_subscribe can be
+// asked to forward.
+type subscriptionKind string
+
+const (
+	subscriptionBonded             subscriptionKind = "bonded"
+	subscriptionStateRootSubmitted subscriptionKind = "stateRootSubmitted"
+	subscriptionChallenged         subscriptionKind = "challenged"
+	subscriptionWithdrawn          subscriptionKind = "withdrawn"
+)
+
+// Subscribe implements the oprollups_subscribe pub/sub endpoint: clients
+// call it as oprollups_subscribe("bonded"|"stateRootSubmitted"|"challenged"|"withdrawn")
+// over a WS/IPC connection and receive a stream of oprollups_subscription
+// notifications carrying the matching event.
+func (a *API) Subscribe(ctx context.Context, kind string) (*gethrpc.Subscription, error) {
+	notifier, supported := gethrpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	switch subscriptionKind(kind) {
+	case subscriptionBonded:
+		go forwardEvents(notifier, rpcSub, a.ori.WatchBonded)
+	case subscriptionStateRootSubmitted:
+		go forwardEvents(notifier, rpcSub, a.ori.WatchStateRootSubmitted)
+	case subscriptionChallenged:
+		go forwardEvents(notifier, rpcSub, a.ori.WatchChallenged)
+	case subscriptionWithdrawn:
+		go forwardEvents(notifier, rpcSub, a.ori.WatchWithdrawn)
+	default:
+		return nil, fmt.Errorf("unknown subscription kind %q", kind)
+	}
+	return rpcSub, nil
+}
+
+// forwardEvents relays events from a Watch* method to notifier for as long
+// as rpcSub stays open, mirroring the reconnect/demultiplex split the
+// oprollups package itself uses for watchEvents: watch owns reconnecting on
+// dropped chain subscriptions, forwardEvents just needs to know when to
+// stop relaying, either because the RPC client unsubscribed or the
+// underlying notifier connection closed.
+func forwardEvents[T any](notifier *gethrpc.Notifier, rpcSub *gethrpc.Subscription, watch func(context.Context, chan<- *T) error) {
+	ch := make(chan *T)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watch(ctx, ch)
+	for {
+		select {
+		case evt := <-ch:
+			notifier.Notify(rpcSub.ID, evt)
+		case <-rpcSub.Err():
+			return
+		case <-notifier.Closed():
+			return
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/rogercoll/oprollups"
+	store "github.com/rogercoll/oprollups/contracts"
+)
+
+// testAPI deploys the Contracts binding on an in-memory simulated.Backend
+// and returns an API wired up against it, along with the RawKeySigner
+// funding the deployment account.
+func testAPI(t *testing.T) (*API, *simulated.Backend, oprollups.Signer) {
+	t.Helper()
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1337))
+	if err != nil {
+		t.Fatalf("transactor: %v", err)
+	}
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		auth.From: {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+	})
+	t.Cleanup(func() { backend.Close() })
+
+	requiredBond := big.NewInt(1e18)
+	lockTime := big.NewInt(60)
+	addr, _, _, err := store.DeployContracts(auth, backend.Client(), requiredBond, lockTime)
+	if err != nil {
+		t.Fatalf("deploy contracts: %v", err)
+	}
+	backend.Commit()
+
+	ori, err := oprollups.New(addr, backend.Client())
+	if err != nil {
+		t.Fatalf("oprollups.New: %v", err)
+	}
+
+	signer, err := oprollups.NewRawKeySigner(hex.EncodeToString(crypto.FromECDSA(privateKey)))
+	if err != nil {
+		t.Fatalf("raw key signer: %v", err)
+	}
+
+	return NewAPI(ori, signer, nil), backend, signer
+}
+
+func TestAPIBondAndLockTime(t *testing.T) {
+	api, backend, _ := testAPI(t)
+
+	if err := api.Bond(context.Background()); err != nil {
+		t.Fatalf("bond: %v", err)
+	}
+	backend.Commit()
+
+	lockTime, err := api.LockTime()
+	if err != nil {
+		t.Fatalf("lock time: %v", err)
+	}
+	if lockTime != 60 {
+		t.Fatalf("lock time = %d, want 60", lockTime)
+	}
+}
+
+func TestAPISubmitBatchRequiresStateTransition(t *testing.T) {
+	api, _, _ := testAPI(t)
+
+	err := api.SubmitBatch(context.Background(), common.Hash{}, common.Hash{}, nil)
+	if err == nil {
+		t.Fatal("expected an error when no StateTransition is configured")
+	}
+}
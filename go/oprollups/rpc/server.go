@@ -0,0 +1,45 @@
+package rpc
+
+import (
+	"github.com/ethereum/go-ethereum/node"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/rogercoll/oprollups"
+)
+
+// Config is node.Config itself, used to stand up a standalone oprollups
+// daemon; it is passed through to node.New verbatim.
+type Config = node.Config
+
+// Server hosts the "oprollups" namespace over HTTP, WS and IPC using
+// go-ethereum's node.Node, the same mechanism it uses to register its own
+// subsystems (eth, net, web3, ...) as rpc.API values.
+type Server struct {
+	node *node.Node
+}
+
+// NewServer builds a Server registering the oprollups namespace backed by
+// ori. st may be nil if this node should never accept oprollups_submitBatch
+// calls.
+func NewServer(cfg Config, ori *oprollups.Oprollups, signer oprollups.Signer, st oprollups.StateTransition) (*Server, error) {
+	stack, err := node.New(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	stack.RegisterAPIs([]gethrpc.API{
+		{
+			Namespace: "oprollups",
+			Service:   NewAPI(ori, signer, st),
+		},
+	})
+	return &Server{node: stack}, nil
+}
+
+// Start brings up the configured HTTP/WS/IPC endpoints.
+func (s *Server) Start() error {
+	return s.node.Start()
+}
+
+// Stop tears down every endpoint and releases the node's resources.
+func (s *Server) Stop() error {
+	return s.node.Close()
+}
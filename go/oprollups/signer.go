@@ -0,0 +1,254 @@
+package oprollups
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/tyler-smith/go-bip32"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// hardenedOffset is added to a BIP-32 path component to derive it as a
+// hardened child key (the "'" suffix in m/44'/60'/0'/0/0 notation).
+const hardenedOffset = uint32(0x80000000)
+
+// ethDerivationPath is the standard Ethereum BIP-44 path, m/44'/60'/0'/0/index,
+// as used by most wallets (Ledger, MetaMask, ...).
+var ethDerivationPath = []uint32{44 + hardenedOffset, 60 + hardenedOffset, 0 + hardenedOffset, 0}
+
+// Signer abstracts away how a transaction gets authorized, so that
+// Oprollups write methods never have to handle raw ECDSA keys. Address
+// reports the account the signer transacts on behalf of, and TransactOpts
+// builds the *bind.TransactOpts used to submit a contract call, auto-filling
+// nonce and gas price from ethClient.
+type Signer interface {
+	Address() common.Address
+	TransactOpts(ctx context.Context, ethClient *ethclient.Client) (*bind.TransactOpts, error)
+}
+
+// baseTransactOpts fetches the pending nonce and suggested gas price for
+// from and returns a *bind.TransactOpts ready to be completed with a
+// concrete Signer's signing callback.
+func baseTransactOpts(ctx context.Context, ethClient *ethclient.Client, from common.Address) (*bind.TransactOpts, error) {
+	nonce, err := ethClient.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, err := ethClient.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &bind.TransactOpts{
+		From:     from,
+		Nonce:    new(big.Int).SetUint64(nonce),
+		GasPrice: gasPrice,
+		Context:  ctx,
+	}, nil
+}
+
+// KeystoreSigner signs through a go-ethereum keystore.KeyStore, mirroring
+// accounts.Manager: the account stays locked until Unlock is called with
+// its passphrase, and TransactOpts re-locks it immediately after signing.
+type KeystoreSigner struct {
+	ks      *keystore.KeyStore
+	account accounts.Account
+}
+
+// NewKeystoreSigner imports the JSON V3 keyfile contents in keyJSON, protected by
+// passphrase, into ks and returns a signer for the resulting account. The
+// account is left locked; call Unlock before the first TransactOpts call.
+func NewKeystoreSigner(ks *keystore.KeyStore, keyJSON []byte, passphrase string) (*KeystoreSigner, error) {
+	account, err := ks.Import(keyJSON, passphrase, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &KeystoreSigner{ks: ks, account: account}, nil
+}
+
+// Unlock unlocks the underlying account so the next TransactOpts call can
+// sign with it.
+func (s *KeystoreSigner) Unlock(passphrase string) error {
+	return s.ks.Unlock(s.account, passphrase)
+}
+
+func (s *KeystoreSigner) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *KeystoreSigner) TransactOpts(ctx context.Context, ethClient *ethclient.Client) (*bind.TransactOpts, error) {
+	opts, err := baseTransactOpts(ctx, ethClient, s.account.Address)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := ethClient.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts.Signer = func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		defer s.ks.Lock(s.account.Address)
+		return s.ks.SignTx(s.account, tx, chainID)
+	}
+	return opts, nil
+}
+
+// HDWalletSigner derives a signing key from a BIP-39 mnemonic using the
+// BIP-44 path m/44'/60'/0'/0/{index}, the standard Ethereum derivation path.
+type HDWalletSigner struct {
+	address    common.Address
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewHDWalletSigner derives account `index` under the Ethereum BIP-44 path
+// from mnemonic.
+func NewHDWalletSigner(mnemonic string, index uint32) (*HDWalletSigner, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("invalid mnemonic")
+	}
+	seed := bip39.NewSeed(mnemonic, "")
+	key, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, component := range append(append([]uint32{}, ethDerivationPath...), index) {
+		key, err = key.NewChildKey(component)
+		if err != nil {
+			return nil, err
+		}
+	}
+	privateKey, err := crypto.ToECDSA(key.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &HDWalletSigner{
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		privateKey: privateKey,
+	}, nil
+}
+
+func (s *HDWalletSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *HDWalletSigner) TransactOpts(ctx context.Context, ethClient *ethclient.Client) (*bind.TransactOpts, error) {
+	return keyedTransactOpts(ctx, ethClient, s.privateKey, s.address)
+}
+
+// RawKeySigner wraps a raw ECDSA private key directly, with no passphrase
+// protection or derivation. It exists for tests and local scripts; use
+// KeystoreSigner or HDWalletSigner for anything touching real funds.
+type RawKeySigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+// NewRawKeySigner builds a RawKeySigner from a hex-encoded private key,
+// matching crypto.HexToECDSA's input format.
+func NewRawKeySigner(hexKey string) (*RawKeySigner, error) {
+	privateKey, err := crypto.HexToECDSA(hexKey)
+	if err != nil {
+		return nil, err
+	}
+	return &RawKeySigner{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}, nil
+}
+
+func (s *RawKeySigner) Address() common.Address {
+	return s.address
+}
+
+func (s *RawKeySigner) TransactOpts(ctx context.Context, ethClient *ethclient.Client) (*bind.TransactOpts, error) {
+	return keyedTransactOpts(ctx, ethClient, s.privateKey, s.address)
+}
+
+// keyedTransactOpts is the TransactOpts implementation shared by the two
+// signers that hold a raw ECDSA key directly in memory.
+func keyedTransactOpts(ctx context.Context, ethClient *ethclient.Client, privateKey *ecdsa.PrivateKey, from common.Address) (*bind.TransactOpts, error) {
+	chainID, err := ethClient.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	if err != nil {
+		return nil, err
+	}
+	base, err := baseTransactOpts(ctx, ethClient, from)
+	if err != nil {
+		return nil, err
+	}
+	opts.Nonce = base.Nonce
+	opts.GasPrice = base.GasPrice
+	opts.Context = ctx
+	return opts, nil
+}
+
+// RemoteSigner signs through a JSON-RPC eth_signTransaction call against an
+// external signer such as Clef or an HSM bridge, so the private key never
+// has to be loaded into this process.
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// NewRemoteSigner connects to a remote signer daemon (e.g. Clef) at rawurl
+// and signs transactions on behalf of address via eth_signTransaction.
+func NewRemoteSigner(ctx context.Context, rawurl string, address common.Address) (*RemoteSigner, error) {
+	client, err := rpc.DialContext(ctx, rawurl)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteSigner{client: client, address: address}, nil
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// signTransactionResult mirrors the object geth's eth_signTransaction
+// returns: the signed transaction, both raw-encoded and decoded.
+type signTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+func (s *RemoteSigner) TransactOpts(ctx context.Context, ethClient *ethclient.Client) (*bind.TransactOpts, error) {
+	base, err := baseTransactOpts(ctx, ethClient, s.address)
+	if err != nil {
+		return nil, err
+	}
+	base.Signer = func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		var result signTransactionResult
+		if err := s.client.CallContext(ctx, &result, "eth_signTransaction", toTransactionArgs(addr, tx)); err != nil {
+			return nil, err
+		}
+		return result.Tx, nil
+	}
+	return base, nil
+}
+
+// toTransactionArgs builds the eth_signTransaction request object from an
+// unsigned *types.Transaction built by bind.TransactOpts.
+func toTransactionArgs(from common.Address, tx *types.Transaction) map[string]interface{} {
+	args := map[string]interface{}{
+		"from":     from,
+		"to":       tx.To(),
+		"gas":      hexutil.Uint64(tx.Gas()),
+		"gasPrice": (*hexutil.Big)(tx.GasPrice()),
+		"value":    (*hexutil.Big)(tx.Value()),
+		"nonce":    hexutil.Uint64(tx.Nonce()),
+		"data":     hexutil.Bytes(tx.Data()),
+	}
+	return args
+}
@@ -0,0 +1,128 @@
+package oprollups
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrLockNotElapsed is returned by Withdraw when user's bond lock period
+// has not elapsed yet.
+type ErrLockNotElapsed struct {
+	// Remaining is how much longer the caller needs to wait before the
+	// bond can be withdrawn.
+	Remaining time.Duration
+}
+
+func (e ErrLockNotElapsed) Error() string {
+	return fmt.Sprintf("bond still locked for %s", e.Remaining)
+}
+
+// Withdraw unbonds user's funds once their lock period has elapsed. If
+// called too early it returns ErrLockNotElapsed instead of issuing a
+// transaction that would just revert on-chain.
+func (ori *Oprollups) Withdraw(ctx context.Context, signer Signer, user common.Address) error {
+	bondedAt, err := ori.ori_contract.BondedAt(nil, user)
+	if err != nil {
+		return err
+	}
+	lockTime, err := ori.ori_contract.LockTime(nil)
+	if err != nil {
+		return err
+	}
+
+	elapsed := time.Since(time.Unix(bondedAt.Int64(), 0))
+	lockPeriod := time.Duration(lockTime.Int64()) * time.Second
+	if elapsed < lockPeriod {
+		return ErrLockNotElapsed{Remaining: lockPeriod - elapsed}
+	}
+
+	opts, err := signer.TransactOpts(ctx, ori.ethClient)
+	if err != nil {
+		return err
+	}
+	_, err = ori.ori_contract.Withdraw(opts, user)
+	return wrapRevertError(err)
+}
+
+// WaitForUnlock blocks until user's bond lock period has elapsed, then
+// closes the returned channel. It races a plain timer against new head
+// notifications so it fires as soon as the lock period elapses rather than
+// only at the next local clock tick, even if bondedAt or the lock period
+// change in the meantime (e.g. a governance update). The channel is also
+// closed when ctx is done or reading the lock state fails (the error is
+// reported on Err), so a closed channel alone does not mean the lock has
+// elapsed.
+func (ori *Oprollups) WaitForUnlock(ctx context.Context, user common.Address) <-chan struct{} {
+	unlocked := make(chan struct{})
+	go func() {
+		defer close(unlocked)
+
+		headers := make(chan *types.Header)
+		sub, err := ori.ethClient.SubscribeNewHead(ctx, headers)
+		// subErr is left nil (and so never selectable) when head
+		// subscriptions aren't supported, e.g. a plain HTTP ethclient.Client
+		// such as the one cmd/main.go constructs: sub.Err() on a failed
+		// subscription would otherwise be read from a subscription that
+		// never fires, or in some transports a nil one, and panic.
+		var subErr <-chan error
+		if err != nil {
+			ori.emitErr(fmt.Errorf("subscribe new head: %w", err))
+		} else {
+			defer sub.Unsubscribe()
+			subErr = sub.Err()
+		}
+
+		for {
+			remaining, ok, err := ori.lockRemaining(ctx, user)
+			if err != nil {
+				ori.emitErr(fmt.Errorf("checking lock state: %w", err))
+				return
+			}
+			if !ok {
+				return
+			}
+
+			timer := time.NewTimer(remaining)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				return
+			case <-headers:
+				timer.Stop()
+				continue
+			case err := <-subErr:
+				timer.Stop()
+				if err != nil {
+					ori.emitErr(fmt.Errorf("head subscription dropped: %w", err))
+				}
+				continue
+			}
+		}
+	}()
+	return unlocked
+}
+
+// lockRemaining reports how long user still needs to wait before their bond
+// unlocks; ok is false once the lock period has already elapsed.
+func (ori *Oprollups) lockRemaining(ctx context.Context, user common.Address) (remaining time.Duration, ok bool, err error) {
+	bondedAt, err := ori.ori_contract.BondedAt(nil, user)
+	if err != nil {
+		return 0, false, err
+	}
+	lockTime, err := ori.ori_contract.LockTime(nil)
+	if err != nil {
+		return 0, false, err
+	}
+	elapsed := time.Since(time.Unix(bondedAt.Int64(), 0))
+	lockPeriod := time.Duration(lockTime.Int64()) * time.Second
+	if elapsed >= lockPeriod {
+		return 0, false, nil
+	}
+	return lockPeriod - elapsed, true, nil
+}
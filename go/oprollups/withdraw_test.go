@@ -0,0 +1,51 @@
+package oprollups
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// TestWaitForUnlockWithoutSubscriptionSupport reproduces the exact setup
+// cmd/main.go uses in production: a plain HTTP ethclient.Client, which does
+// not support eth_subscribe. SubscribeNewHead fails immediately in that
+// case, and WaitForUnlock must keep working off the plain timer instead of
+// dereferencing the failed subscription.
+func TestWaitForUnlockWithoutSubscriptionSupport(t *testing.T) {
+	backend, auth, contract := testChain(t)
+
+	// "http://" is enough to make the RPC client refuse subscriptions
+	// client-side; it never needs to actually reach this address.
+	httpClient, err := ethclient.Dial("http://127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+
+	ori := &Oprollups{
+		ori_contract:  contract,
+		ethClient:     httpClient,
+		required_bond: big.NewInt(1e18),
+		errCh:         make(chan error, 1),
+		logger:        &stdLogger{},
+	}
+
+	bondOpts := *auth
+	bondOpts.Value = big.NewInt(1e18)
+	if _, err := contract.Bond(&bondOpts, auth.From); err != nil {
+		t.Fatalf("bond: %v", err)
+	}
+	backend.Commit()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	done := ori.WaitForUnlock(ctx, auth.From)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitForUnlock never returned; it likely panicked in its goroutine")
+	}
+}